@@ -0,0 +1,72 @@
+package lipgloss
+
+import "strings"
+
+// alignTextHorizontal aligns the lines of str to fit inside width, padding
+// with spaces according to pos (0 = left, 0.5 = center, 1 = right). Lines are
+// measured and padded by grapheme cluster, so wide runes and multi-codepoint
+// emoji don't throw off the padding.
+func alignTextHorizontal(str string, pos Position, width int) string {
+	lines, widestLine := getLines(str)
+	if width < widestLine {
+		width = widestLine
+	}
+
+	var b strings.Builder
+	for i, l := range lines {
+		lineWidth := printableWidth(l)
+		shortAmount := width - lineWidth
+		if shortAmount > 0 {
+			switch pos {
+			case Left:
+				l += strings.Repeat(" ", shortAmount)
+			case Right:
+				l = strings.Repeat(" ", shortAmount) + l
+			default:
+				left := shortAmount * int(pos*100) / 100
+				right := shortAmount - left
+				l = strings.Repeat(" ", left) + l + strings.Repeat(" ", right)
+			}
+		}
+		b.WriteString(l)
+		if i < len(lines)-1 {
+			b.WriteRune('\n')
+		}
+	}
+	return b.String()
+}
+
+// alignTextVertical pads str with blank lines to height, placing the
+// existing content according to pos (0 = top, 0.5 = middle, 1 = bottom).
+func alignTextVertical(str string, pos Position, height int) string {
+	lines, widestLine := getLines(str)
+	if height < len(lines) {
+		return str
+	}
+
+	extra := height - len(lines)
+
+	var top int
+	switch pos {
+	case Top:
+		top = 0
+	case Bottom:
+		top = extra
+	default:
+		top = extra * int(pos*100) / 100
+	}
+	bottom := extra - top
+
+	blank := strings.Repeat(" ", widestLine)
+	var b strings.Builder
+	for i := 0; i < top; i++ {
+		b.WriteString(blank)
+		b.WriteRune('\n')
+	}
+	b.WriteString(str)
+	for i := 0; i < bottom; i++ {
+		b.WriteRune('\n')
+		b.WriteString(blank)
+	}
+	return b.String()
+}
@@ -2,10 +2,11 @@ package lipgloss
 
 import (
 	"strings"
+	"sync"
+	"unicode/utf8"
 
-	"github.com/mattn/go-runewidth"
-	"github.com/muesli/reflow/ansi"
 	"github.com/muesli/termenv"
+	"github.com/rivo/uniseg"
 )
 
 // Border contains a series of values which comprise the various parts of a
@@ -19,39 +20,48 @@ type Border struct {
 	TopRight    string
 	BottomRight string
 	BottomLeft  string
+
+	// ASCII, when set, provides the glyphs to substitute for this border's
+	// fields when rendering in ASCII-only mode (see SetUnicode and
+	// Style.ASCIIOnly). Fields left as the empty string fall back to the
+	// package-level table registered with RegisterASCIIFallback.
+	ASCII *Border
 }
 
-// GetTopSize returns the width of the top border. If borders contain runes of
-// varying widths, the widest rune is returned. If no border exists on the top
-// edge, 0 is returned.
+// GetTopSize returns the width of the top border. If border pieces contain
+// varying widths (including multi-rune corners), the widest piece is
+// returned. If no border exists on the top edge, 0 is returned.
 func (b Border) GetTopSize() int {
 	return getBorderEdgeWidth(b.TopLeft, b.Top, b.TopRight)
 }
 
-// GetRightSize returns the width of the right border. If borders contain
-// runes of varying widths, the widest rune is returned. If no border exists on
-// the right edge, 0 is returned.
+// GetRightSize returns the width of the right border. If border pieces
+// contain varying widths (including multi-rune corners), the widest piece is
+// returned. If no border exists on the right edge, 0 is returned.
 func (b Border) GetRightSize() int {
 	return getBorderEdgeWidth(b.TopRight, b.Top, b.BottomRight)
 }
 
-// GetBottomSize returns the width of the bottom border. If borders contain
-// runes of varying widths, the widest rune is returned. If no border exists on
-// the bottom edge, 0 is returned.
+// GetBottomSize returns the width of the bottom border. If border pieces
+// contain varying widths (including multi-rune corners), the widest piece is
+// returned. If no border exists on the bottom edge, 0 is returned.
 func (b Border) GetBottomSize() int {
 	return getBorderEdgeWidth(b.BottomLeft, b.Bottom, b.BottomRight)
 }
 
-// GetLeftSize returns the width of the left border. If borders contain runes
-// of varying widths, the widest rune is returned. If no border exists on the
-// left edge, 0 is returned.
+// GetLeftSize returns the width of the left border. If border pieces contain
+// varying widths (including multi-rune corners), the widest piece is
+// returned. If no border exists on the left edge, 0 is returned.
 func (b Border) GetLeftSize() int {
 	return getBorderEdgeWidth(b.TopLeft, b.Left, b.TopRight)
 }
 
+// getBorderEdgeWidth returns the widest of the given border pieces, measured
+// by total printable width rather than by widest single grapheme cluster, so
+// that multi-rune corners (e.g. "╔═") are sized correctly.
 func getBorderEdgeWidth(borderParts ...string) (maxWidth int) {
 	for _, piece := range borderParts {
-		w := maxRuneWidth(piece)
+		w := printableWidth(piece)
 		if w > maxWidth {
 			maxWidth = w
 		}
@@ -116,6 +126,28 @@ var (
 		BottomLeft:  " ",
 		BottomRight: " ",
 	}
+
+	blockBorder = Border{
+		Top:         "▀",
+		Bottom:      "▄",
+		Left:        "▌",
+		Right:       "▐",
+		TopLeft:     "▛",
+		TopRight:    "▜",
+		BottomLeft:  "▙",
+		BottomRight: "▟",
+	}
+
+	thinBlockBorder = Border{
+		Top:         "🮂",
+		Bottom:      "🮃",
+		Left:        "▏",
+		Right:       "▕",
+		TopLeft:     "▏",
+		TopRight:    "▕",
+		BottomLeft:  "▏",
+		BottomRight: "▕",
+	}
 )
 
 // NormalBorder returns a standard-type border with a normal weight and 90
@@ -140,6 +172,184 @@ func DoubleBorder() Border {
 	return doubleBorder
 }
 
+// unicodeEnabled controls whether applyBorder renders the Unicode
+// box-drawing glyphs of a border as-is, or substitutes ASCII fallbacks. It's
+// toggled package-wide with SetUnicode; a Style that sets ASCIIOnly
+// overrides it for that style.
+// It's guarded by unicodeMu since it's read from Render on any goroutine.
+var (
+	unicodeMu      sync.RWMutex
+	unicodeEnabled = true
+)
+
+// SetUnicode sets whether borders are rendered with their native Unicode
+// glyphs (the default) or with ASCII fallbacks. Disable this when targeting
+// legacy terminals, CI logs, serial consoles, or any other output where
+// box-drawing characters won't render correctly. This function is
+// thread-safe.
+func SetUnicode(v bool) {
+	unicodeMu.Lock()
+	defer unicodeMu.Unlock()
+	unicodeEnabled = v
+}
+
+func isUnicodeEnabled() bool {
+	unicodeMu.RLock()
+	defer unicodeMu.RUnlock()
+	return unicodeEnabled
+}
+
+// asciiFallback maps the built-in borders' Unicode glyphs to ASCII
+// equivalents, used when rendering in ASCII-only mode. Register additional
+// mappings with RegisterASCIIFallback. It's guarded by asciiFallbackMu since
+// it's read from Render on any goroutine.
+var (
+	asciiFallbackMu sync.RWMutex
+	asciiFallback   = map[rune]rune{
+		'─': '-', '━': '-', '═': '-',
+		'│': '|', '┃': '|', '║': '|',
+		'┌': '+', '┐': '+', '└': '+', '┘': '+',
+		'╭': '+', '╮': '+', '╰': '+', '╯': '+',
+		'┏': '+', '┓': '+', '┗': '+', '┛': '+',
+		'╔': '+', '╗': '+', '╚': '+', '╝': '+',
+	}
+)
+
+// RegisterASCIIFallback registers an ASCII substitute for r, used when
+// rendering a border in ASCII-only mode (see SetUnicode and
+// Style.ASCIIOnly). It allows callers with custom border glyphs to extend
+// the built-in fallback table instead of supplying a full Border.ASCII
+// companion struct. This function is thread-safe.
+func RegisterASCIIFallback(r, ascii rune) {
+	asciiFallbackMu.Lock()
+	defer asciiFallbackMu.Unlock()
+	asciiFallback[r] = ascii
+}
+
+func lookupASCIIFallback(r rune) (rune, bool) {
+	asciiFallbackMu.RLock()
+	defer asciiFallbackMu.RUnlock()
+	a, ok := asciiFallback[r]
+	return a, ok
+}
+
+// toASCII returns a copy of b with each field substituted for its ASCII
+// fallback. A non-nil b.ASCII takes precedence, field by field, over the
+// package-level fallback table.
+func (b Border) toASCII() Border {
+	sub := func(s string) string {
+		if s == "" {
+			return s
+		}
+		var out strings.Builder
+		for _, r := range s {
+			if a, ok := lookupASCIIFallback(r); ok {
+				out.WriteRune(a)
+				continue
+			}
+			out.WriteRune(r)
+		}
+		return out.String()
+	}
+
+	if b.ASCII != nil {
+		if b.ASCII.Top != "" {
+			b.Top = b.ASCII.Top
+		}
+		if b.ASCII.Bottom != "" {
+			b.Bottom = b.ASCII.Bottom
+		}
+		if b.ASCII.Left != "" {
+			b.Left = b.ASCII.Left
+		}
+		if b.ASCII.Right != "" {
+			b.Right = b.ASCII.Right
+		}
+		if b.ASCII.TopLeft != "" {
+			b.TopLeft = b.ASCII.TopLeft
+		}
+		if b.ASCII.TopRight != "" {
+			b.TopRight = b.ASCII.TopRight
+		}
+		if b.ASCII.BottomLeft != "" {
+			b.BottomLeft = b.ASCII.BottomLeft
+		}
+		if b.ASCII.BottomRight != "" {
+			b.BottomRight = b.ASCII.BottomRight
+		}
+	}
+
+	b.Top = sub(b.Top)
+	b.Bottom = sub(b.Bottom)
+	b.Left = sub(b.Left)
+	b.Right = sub(b.Right)
+	b.TopLeft = sub(b.TopLeft)
+	b.TopRight = sub(b.TopRight)
+	b.BottomLeft = sub(b.BottomLeft)
+	b.BottomRight = sub(b.BottomRight)
+
+	return b
+}
+
+// ASCIIOnly sets whether this style renders its border with ASCII fallbacks
+// instead of Unicode box-drawing glyphs, overriding the package-wide default
+// set with SetUnicode.
+func (s Style) ASCIIOnly(v bool) Style {
+	s.set(asciiOnlyKey, v)
+	return s
+}
+
+// GetASCIIOnly returns whether this style is set to render its border with
+// ASCII fallbacks.
+func (s Style) GetASCIIOnly() bool {
+	return s.getAsBool(asciiOnlyKey, false)
+}
+
+// BorderTopLabel sets a label to be rendered inline on the top border edge,
+// e.g. ┌─ Logs ──────┐. Its position along the edge is controlled with
+// BorderLabelPosition. The label is measured with printableWidth, so a
+// pre-styled (ANSI-wrapped) label is supported and measured correctly.
+// If the label doesn't fit alongside the corners it's truncated with an
+// ellipsis.
+func (s Style) BorderTopLabel(label string) Style {
+	s.set(borderTopLabelKey, label)
+	return s
+}
+
+// BorderBottomLabel sets a label to be rendered inline on the bottom border
+// edge. See BorderTopLabel for details.
+func (s Style) BorderBottomLabel(label string) Style {
+	s.set(borderBottomLabelKey, label)
+	return s
+}
+
+// BorderLabelPosition sets where along the top and bottom border edges a
+// label set with BorderTopLabel or BorderBottomLabel is rendered. Use Left,
+// Center (the default), or Right, or any Position in between for a
+// fractional offset.
+func (s Style) BorderLabelPosition(pos Position) Style {
+	s.set(borderLabelPositionKey, pos)
+	return s
+}
+
+// GetBorderTopLabel returns the style's top border label, if any.
+func (s Style) GetBorderTopLabel() string {
+	return s.getAsString(borderTopLabelKey)
+}
+
+// GetBorderBottomLabel returns the style's bottom border label, if any.
+func (s Style) GetBorderBottomLabel() string {
+	return s.getAsString(borderBottomLabelKey)
+}
+
+// GetBorderLabelPosition returns where the style renders its border labels.
+func (s Style) GetBorderLabelPosition() Position {
+	if !s.isSet(borderLabelPositionKey) {
+		return Center
+	}
+	return s.getAsPosition(borderLabelPositionKey)
+}
+
 // HiddenBorder returns a border that renders as a series of single-cell
 // spaces. It's useful for cases when you want to remove a standard border but
 // maintain layout positioning. This said, you can still apply a background
@@ -148,6 +358,114 @@ func HiddenBorder() Border {
 	return hiddenBorder
 }
 
+// BlockBorder returns a border built from full and half block elements. Its
+// edges butt right up against adjacent content with no gap, which makes it
+// useful when BorderBackground is set to a color other than the content's
+// background: the border appears to "contain" the content rather than
+// framing it with a line.
+func BlockBorder() Border {
+	return blockBorder
+}
+
+// ThinBlockBorder returns a border whose sides and corners are the left and
+// right one-eighth block elements, and whose top and bottom edges are the
+// upper one-quarter and upper three-eighths blocks from the "Symbols for
+// Legacy Computing" block. It has the same edge-to-edge,
+// no-gap property as BlockBorder but with a lighter weight.
+func ThinBlockBorder() Border {
+	return thinBlockBorder
+}
+
+// HorizontalBorder sets the border style and limits it to the top and
+// bottom edges, turning the left and right edges off. It's shorthand for:
+//
+//	s.Border(b, true, false, true, false)
+func (s Style) HorizontalBorder(b Border) Style {
+	return s.Border(b, true, false, true, false)
+}
+
+// VerticalBorder sets the border style and limits it to the left and right
+// edges, turning the top and bottom edges off. It's shorthand for:
+//
+//	s.Border(b, false, true, false, true)
+func (s Style) VerticalBorder(b Border) Style {
+	return s.Border(b, false, true, false, true)
+}
+
+// TopBorderOnly sets the border style and limits it to the top edge. It's
+// shorthand for:
+//
+//	s.Border(b, true, false, false, false)
+func (s Style) TopBorderOnly(b Border) Style {
+	return s.Border(b, true, false, false, false)
+}
+
+// RightBorderOnly sets the border style and limits it to the right edge.
+// It's shorthand for:
+//
+//	s.Border(b, false, true, false, false)
+func (s Style) RightBorderOnly(b Border) Style {
+	return s.Border(b, false, true, false, false)
+}
+
+// BottomBorderOnly sets the border style and limits it to the bottom edge.
+// It's shorthand for:
+//
+//	s.Border(b, false, false, true, false)
+func (s Style) BottomBorderOnly(b Border) Style {
+	return s.Border(b, false, false, true, false)
+}
+
+// LeftBorderOnly sets the border style and limits it to the left edge. It's
+// shorthand for:
+//
+//	s.Border(b, false, false, false, true)
+func (s Style) LeftBorderOnly(b Border) Style {
+	return s.Border(b, false, false, false, true)
+}
+
+// GetHorizontalBorder returns whether or not the style is set to render a
+// border on the top and bottom edges only.
+func (s Style) GetHorizontalBorder() bool {
+	return s.getAsBool(borderTopKey, false) && s.getAsBool(borderBottomKey, false) &&
+		!s.getAsBool(borderLeftKey, false) && !s.getAsBool(borderRightKey, false)
+}
+
+// GetVerticalBorder returns whether or not the style is set to render a
+// border on the left and right edges only.
+func (s Style) GetVerticalBorder() bool {
+	return s.getAsBool(borderLeftKey, false) && s.getAsBool(borderRightKey, false) &&
+		!s.getAsBool(borderTopKey, false) && !s.getAsBool(borderBottomKey, false)
+}
+
+// GetTopBorderOnly returns whether or not the style is set to render a
+// border on the top edge only.
+func (s Style) GetTopBorderOnly() bool {
+	return s.getAsBool(borderTopKey, false) &&
+		!s.getAsBool(borderRightKey, false) && !s.getAsBool(borderBottomKey, false) && !s.getAsBool(borderLeftKey, false)
+}
+
+// GetRightBorderOnly returns whether or not the style is set to render a
+// border on the right edge only.
+func (s Style) GetRightBorderOnly() bool {
+	return s.getAsBool(borderRightKey, false) &&
+		!s.getAsBool(borderTopKey, false) && !s.getAsBool(borderBottomKey, false) && !s.getAsBool(borderLeftKey, false)
+}
+
+// GetBottomBorderOnly returns whether or not the style is set to render a
+// border on the bottom edge only.
+func (s Style) GetBottomBorderOnly() bool {
+	return s.getAsBool(borderBottomKey, false) &&
+		!s.getAsBool(borderTopKey, false) && !s.getAsBool(borderRightKey, false) && !s.getAsBool(borderLeftKey, false)
+}
+
+// GetLeftBorderOnly returns whether or not the style is set to render a
+// border on the left edge only.
+func (s Style) GetLeftBorderOnly() bool {
+	return s.getAsBool(borderLeftKey, false) &&
+		!s.getAsBool(borderTopKey, false) && !s.getAsBool(borderRightKey, false) && !s.getAsBool(borderBottomKey, false)
+}
+
 func (s Style) applyBorder(str string) string {
 	var (
 		topSet    = s.isSet(borderTopKey)
@@ -170,6 +488,10 @@ func (s Style) applyBorder(str string) string {
 		rightBG  = s.getAsColor(borderRightBackgroundKey)
 		bottomBG = s.getAsColor(borderBottomBackgroundKey)
 		leftBG   = s.getAsColor(borderLeftBackgroundKey)
+
+		topLabel    = s.getAsString(borderTopLabelKey)
+		bottomLabel = s.getAsString(borderBottomLabelKey)
+		labelPos    = s.GetBorderLabelPosition()
 	)
 
 	// If a border is set and no sides have been specifically turned on or off
@@ -186,7 +508,8 @@ func (s Style) applyBorder(str string) string {
 		return str
 	}
 
-	lines, width := getLines(str)
+	lines, contentWidth := getLines(str)
+	width := contentWidth
 
 	if hasLeft {
 		if border.Left == "" {
@@ -195,8 +518,11 @@ func (s Style) applyBorder(str string) string {
 		width += maxRuneWidth(border.Left)
 	}
 
-	if hasRight && border.Right == "" {
-		border.Right = " "
+	if hasRight {
+		if border.Right == "" {
+			border.Right = " "
+		}
+		width += maxRuneWidth(border.Right)
 	}
 
 	// If corners should be render but are set with the empty string, fill them
@@ -239,17 +565,49 @@ func (s Style) applyBorder(str string) string {
 		}
 	}
 
-	// For now, limit corners to one rune.
-	border.TopLeft = getFirstRuneAsString(border.TopLeft)
-	border.TopRight = getFirstRuneAsString(border.TopRight)
-	border.BottomRight = getFirstRuneAsString(border.BottomRight)
-	border.BottomLeft = getFirstRuneAsString(border.BottomLeft)
+	asciiOnly := !isUnicodeEnabled()
+	if s.isSet(asciiOnlyKey) {
+		asciiOnly = s.getAsBool(asciiOnlyKey, false)
+	}
+	if asciiOnly {
+		border = border.toASCII()
+	}
+
+	// Multi-rune corners can be wider than the content-plus-sides width we
+	// just computed. When that happens, widen the whole border to the
+	// corner width and pad the content lines to match, so the top/bottom
+	// edges and the content rows come out the same width instead of
+	// flaring out past the sides.
+	if hasTop {
+		if w := printableWidth(border.TopLeft) + printableWidth(border.TopRight); w > width {
+			width = w
+		}
+	}
+	if hasBottom {
+		if w := printableWidth(border.BottomLeft) + printableWidth(border.BottomRight); w > width {
+			width = w
+		}
+	}
+
+	sideWidth := 0
+	if hasLeft {
+		sideWidth += maxRuneWidth(border.Left)
+	}
+	if hasRight {
+		sideWidth += maxRuneWidth(border.Right)
+	}
+	if pad := width - sideWidth - contentWidth; pad > 0 {
+		fill := strings.Repeat(" ", pad)
+		for i := range lines {
+			lines[i] += fill
+		}
+	}
 
 	var out strings.Builder
 
 	// Render top
 	if hasTop {
-		top := renderHorizontalEdge(border.TopLeft, border.Top, border.TopRight, width)
+		top := renderHorizontalEdgeWithLabel(border.TopLeft, border.Top, border.TopRight, topLabel, labelPos, width)
 		top = styleBorder(top, topFG, topBG)
 		out.WriteString(top)
 		out.WriteRune('\n')
@@ -287,7 +645,7 @@ func (s Style) applyBorder(str string) string {
 
 	// Render bottom
 	if hasBottom {
-		bottom := renderHorizontalEdge(border.BottomLeft, border.Bottom, border.BottomRight, width)
+		bottom := renderHorizontalEdgeWithLabel(border.BottomLeft, border.Bottom, border.BottomRight, bottomLabel, labelPos, width)
 		bottom = styleBorder(bottom, bottomFG, bottomBG)
 		out.WriteRune('\n')
 		out.WriteString(bottom)
@@ -296,37 +654,193 @@ func (s Style) applyBorder(str string) string {
 	return out.String()
 }
 
-// Render the horizontal (top or bottom) portion of a border.
+// Render the horizontal (top or bottom) portion of a border. width is the
+// full rendered width of the edge, corners included, so that it lines up
+// with the content rows regardless of how wide left/right or the corners
+// themselves are.
 func renderHorizontalEdge(left, middle, right string, width int) string {
 	if width < 1 {
 		return ""
 	}
 
+	leftWidth := printableWidth(left)
+	rightWidth := printableWidth(right)
+
+	out := strings.Builder{}
+	out.WriteString(left)
+	out.WriteString(repeatEdge(middle, width-leftWidth-rightWidth))
+	out.WriteString(right)
+
+	return out.String()
+}
+
+// renderHorizontalEdgeWithLabel is like renderHorizontalEdge but, when label
+// is non-empty, splices it into the run at pos. The label is measured with
+// printableWidth so pre-styled (ANSI-wrapped) labels are measured correctly.
+// If the label doesn't fit alongside the corners, it's truncated with an
+// ellipsis; if it still doesn't fit, it's dropped.
+func renderHorizontalEdgeWithLabel(left, middle, right, label string, pos Position, width int) string {
+	if label == "" {
+		return renderHorizontalEdge(left, middle, right, width)
+	}
+
+	leftWidth := printableWidth(left)
+	rightWidth := printableWidth(right)
+	avail := width - leftWidth - rightWidth
+
+	if avail < 1 {
+		return renderHorizontalEdge(left, middle, right, width)
+	}
+
+	label = truncateLabel(label, avail)
+	labelWidth := printableWidth(label)
+
+	gap := avail - labelWidth
+	headWidth := int(float64(gap) * float64(pos))
+	tailWidth := gap - headWidth
+
+	out := strings.Builder{}
+	out.WriteString(left)
+	out.WriteString(repeatEdge(middle, headWidth))
+	out.WriteString(label)
+	out.WriteString(repeatEdge(middle, tailWidth))
+	out.WriteString(right)
+
+	return out.String()
+}
+
+// repeatEdge fills width printable cells by repeating middle, wrapping back
+// to its first grapheme cluster when exhausted, so a multi-codepoint filler
+// (an emoji, say) isn't split mid-cluster. If middle is empty a space is
+// used.
+func repeatEdge(middle string, width int) string {
+	if width < 1 {
+		return ""
+	}
+
 	if middle == "" {
 		middle = " "
 	}
 
-	leftWidth := ansi.PrintableRuneWidth(left)
-	rightWidth := ansi.PrintableRuneWidth(right)
-
-	runes := []rune(middle)
+	clusters := graphemeClusters(middle)
 	j := 0
 
 	out := strings.Builder{}
-	out.WriteString(left)
-	for i := leftWidth + rightWidth; i < width+rightWidth; {
-		out.WriteRune(runes[j])
+	for i := 0; i < width; {
+		out.WriteString(clusters[j])
+		i += uniseg.StringWidth(clusters[j])
 		j++
-		if j >= len(runes) {
+		if j >= len(clusters) {
 			j = 0
 		}
-		i += ansi.PrintableRuneWidth(string(runes[j]))
 	}
-	out.WriteString(right)
 
 	return out.String()
 }
 
+// truncateLabel shortens label to fit within width printable cells, adding
+// an ellipsis if it was shortened. If width is too small to fit even an
+// ellipsis, the empty string is returned.
+func truncateLabel(label string, width int) string {
+	if printableWidth(label) <= width {
+		return label
+	}
+
+	if width < 1 {
+		return ""
+	}
+
+	const ellipsis = "…"
+	if width == 1 {
+		return ellipsis
+	}
+
+	var (
+		out      strings.Builder
+		w        int
+		inEscape bool
+	)
+
+	// Copy ANSI escape sequences through untouched (they cost no width) and
+	// only count and cut printable clusters, so a pre-styled label doesn't
+	// have its opening escape severed from its content.
+	for _, cluster := range graphemeClusters(label) {
+		r, _ := utf8.DecodeRuneInString(cluster)
+
+		if inEscape {
+			out.WriteString(cluster)
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				inEscape = false
+			}
+			continue
+		}
+
+		if r == '\x1b' {
+			inEscape = true
+			out.WriteString(cluster)
+			continue
+		}
+
+		cw := uniseg.StringWidth(cluster)
+		if w+cw > width-1 {
+			break
+		}
+		out.WriteString(cluster)
+		w += cw
+	}
+	out.WriteString(ellipsis)
+
+	// If the label was pre-styled and carried a trailing reset, make sure
+	// truncation didn't cut it off — otherwise the label's color would leak
+	// into the rest of the border.
+	if strings.Contains(label, "\x1b[0m") && !strings.HasSuffix(out.String(), "\x1b[0m") {
+		out.WriteString("\x1b[0m")
+	}
+
+	return out.String()
+}
+
+// graphemeClusters splits s into its grapheme clusters.
+func graphemeClusters(s string) []string {
+	var clusters []string
+	gr := uniseg.NewGraphemes(s)
+	for gr.Next() {
+		clusters = append(clusters, gr.Str())
+	}
+	return clusters
+}
+
+// printableWidth returns the total display width of s, skipping ANSI escape
+// sequences and measuring by grapheme cluster so that a multi-codepoint
+// sequence (a ZWJ emoji, a flag, a skin-tone modifier) counts as one
+// cluster, two cells wide for such emoji, rather than as however many code
+// points it's made of.
+func printableWidth(s string) (width int) {
+	var inEscape bool
+
+	gr := uniseg.NewGraphemes(s)
+	for gr.Next() {
+		cluster := gr.Str()
+		r, _ := utf8.DecodeRuneInString(cluster)
+
+		if inEscape {
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				inEscape = false
+			}
+			continue
+		}
+
+		if r == '\x1b' {
+			inEscape = true
+			continue
+		}
+
+		width += uniseg.StringWidth(cluster)
+	}
+
+	return width
+}
+
 //TODO add DoeFoot methods for styleBorder (only other ColorProfile() caller)
 // Apply foreground and background styling to a border.
 func styleBorder(border string, fg, bg TerminalColor) string {
@@ -346,20 +860,17 @@ func styleBorder(border string, fg, bg TerminalColor) string {
 	return style.Styled(border)
 }
 
+// maxRuneWidth returns the widest grapheme cluster in str. Iterating by
+// grapheme cluster, rather than by rune, ensures emoji sequences joined with
+// ZWJ, regional indicators (flags), and skin-tone modifiers are measured as
+// one cluster (two cells wide for such emoji) rather than as however many
+// code points they're made of.
 func maxRuneWidth(str string) (width int) {
-	for _, r := range str {
-		w := runewidth.RuneWidth(r)
-		if w > width {
+	gr := uniseg.NewGraphemes(str)
+	for gr.Next() {
+		if w := uniseg.StringWidth(gr.Str()); w > width {
 			width = w
 		}
 	}
 	return width
 }
-
-func getFirstRuneAsString(str string) string {
-	if str == "" {
-		return str
-	}
-	r := []rune(str)
-	return string(r[0])
-}
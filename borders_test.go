@@ -0,0 +1,328 @@
+package lipgloss
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func stripANSI(s string) string {
+	return ansiRegex.ReplaceAllString(s, "")
+}
+
+func TestBlockBorder(t *testing.T) {
+	style := NewStyle().Border(BlockBorder()).Padding(0, 1)
+
+	got := style.Render("hi")
+	want := "▛▀▀▀▀▜\n▌ hi ▐\n▙▄▄▄▄▟"
+
+	if got != want {
+		t.Errorf("BlockBorder rendering mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestBlockBorderWithBackground(t *testing.T) {
+	style := NewStyle().
+		Border(BlockBorder()).
+		BorderBackground(Color("63")).
+		Padding(0, 1)
+
+	got := stripANSI(style.Render("hi"))
+	want := "▛▀▀▀▀▜\n▌ hi ▐\n▙▄▄▄▄▟"
+
+	if got != want {
+		t.Errorf("BlockBorder with background rendering mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestHorizontalBorder(t *testing.T) {
+	style := NewStyle().HorizontalBorder(NormalBorder())
+
+	if !style.GetHorizontalBorder() {
+		t.Error("expected GetHorizontalBorder to report true after HorizontalBorder")
+	}
+	if style.GetVerticalBorder() {
+		t.Error("expected GetVerticalBorder to report false after HorizontalBorder")
+	}
+
+	got := style.Render("hi")
+	want := "──\nhi\n──"
+
+	if got != want {
+		t.Errorf("HorizontalBorder rendering mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestVerticalBorder(t *testing.T) {
+	style := NewStyle().VerticalBorder(NormalBorder())
+
+	if !style.GetVerticalBorder() {
+		t.Error("expected GetVerticalBorder to report true after VerticalBorder")
+	}
+	if style.GetHorizontalBorder() {
+		t.Error("expected GetHorizontalBorder to report false after VerticalBorder")
+	}
+
+	got := style.Render("hi")
+	want := "│hi│"
+
+	if got != want {
+		t.Errorf("VerticalBorder rendering mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTopBorderOnly(t *testing.T) {
+	style := NewStyle().TopBorderOnly(NormalBorder())
+
+	if !style.GetTopBorderOnly() {
+		t.Error("expected GetTopBorderOnly to report true after TopBorderOnly")
+	}
+
+	got := style.Render("hi")
+	want := "──\nhi"
+
+	if got != want {
+		t.Errorf("TopBorderOnly rendering mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestBorderTopLabel(t *testing.T) {
+	style := NewStyle().Border(NormalBorder()).Width(10).BorderTopLabel(" Logs ")
+
+	got := style.Render("")
+	want := "┌── Logs ──┐\n│          │\n└──────────┘"
+
+	if got != want {
+		t.Errorf("top border label mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestBorderLabelPositionLeft(t *testing.T) {
+	style := NewStyle().Border(NormalBorder()).Width(10).
+		BorderTopLabel(" Logs ").BorderLabelPosition(Left)
+
+	got := style.Render("")
+	want := "┌ Logs ────┐\n│          │\n└──────────┘"
+
+	if got != want {
+		t.Errorf("left-positioned border label mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestBorderTopLabelTruncation(t *testing.T) {
+	style := NewStyle().Border(NormalBorder()).Width(2).BorderTopLabel("a very long label")
+
+	got := style.Render("")
+	if got == "" {
+		t.Fatal("expected non-empty render")
+	}
+
+	want := "┌a…┐\n│  │\n└──┘"
+	if got != want {
+		t.Errorf("truncated border label mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTruncateLabelPreservesANSIReset(t *testing.T) {
+	label := "\x1b[31mRed Label Text\x1b[0m"
+
+	got := truncateLabel(label, 5)
+	want := "\x1b[31mRed …\x1b[0m"
+
+	if got != want {
+		t.Errorf("truncateLabel(%q, 5) = %q, want %q", label, got, want)
+	}
+
+	if !strings.HasSuffix(got, "\x1b[0m") {
+		t.Errorf("truncateLabel(%q, 5) = %q, dropped its trailing reset", label, got)
+	}
+}
+
+func TestMaxRuneWidthGraphemeCluster(t *testing.T) {
+	// U+1F468 U+200D U+1F469 U+200D U+1F467 = family emoji (man, ZWJ, woman,
+	// ZWJ, girl), a single grapheme cluster that should measure as one
+	// double-width cell, not three.
+	family := "\U0001F468‍\U0001F469‍\U0001F467"
+
+	if got := maxRuneWidth(family); got != 2 {
+		t.Errorf("maxRuneWidth(%q) = %d, want 2", family, got)
+	}
+}
+
+func TestEmojiFlagCorners(t *testing.T) {
+	// 🇺🇸 is a two-codepoint regional-indicator pair that terminals render
+	// as a single double-width flag glyph, not two separate cells.
+	flag := "🇺🇸"
+
+	b := Border{
+		Top:      "─",
+		Bottom:   "─",
+		Left:     "│",
+		Right:    "│",
+		TopLeft:  flag,
+		TopRight: flag,
+	}
+
+	style := NewStyle().Border(b, true, true, false, true)
+
+	got := style.Render("hi")
+	want := "🇺🇸🇺🇸\n│hi│"
+
+	if got != want {
+		t.Errorf("emoji flag corner rendering mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestZWJEmojiAsEdgeFiller(t *testing.T) {
+	// The family emoji is a single five-codepoint grapheme cluster; used as
+	// a repeating edge filler it must not be split mid-cluster.
+	family := "\U0001F468‍\U0001F469‍\U0001F467"
+
+	style := NewStyle().Border(Border{Top: family, Bottom: family}, true, false, true, false)
+
+	got := style.Render("ok")
+	want := family + "\nok\n" + family
+
+	if got != want {
+		t.Errorf("ZWJ emoji edge filler rendering mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMultiRuneCorners(t *testing.T) {
+	wide := Border{
+		Top:         "═",
+		Bottom:      "═",
+		Left:        "║",
+		Right:       "║",
+		TopLeft:     "╔═",
+		TopRight:    "═╗",
+		BottomLeft:  "╚═",
+		BottomRight: "═╝",
+	}
+
+	style := NewStyle().Border(wide)
+
+	got := style.Render("hi")
+	want := "╔══╗\n║hi║\n╚══╝"
+
+	if got != want {
+		t.Errorf("multi-rune corner rendering mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMultiRuneCornersNarrowerContent(t *testing.T) {
+	// Same border as TestMultiRuneCorners, but with content narrower than
+	// the corner pair, so the rows must be padded to stay rectangular.
+	wide := Border{
+		Top:         "═",
+		Bottom:      "═",
+		Left:        "║",
+		Right:       "║",
+		TopLeft:     "╔═",
+		TopRight:    "═╗",
+		BottomLeft:  "╚═",
+		BottomRight: "═╝",
+	}
+
+	style := NewStyle().Border(wide)
+
+	got := style.Render("h")
+	want := "╔══╗\n║h ║\n╚══╝"
+
+	if got != want {
+		t.Errorf("multi-rune corner rendering mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	for i, line := range strings.Split(got, "\n") {
+		if w := printableWidth(line); w != 4 {
+			t.Errorf("line %d (%q) has width %d, want 4 (box is not rectangular)", i, line, w)
+		}
+	}
+}
+
+func TestGetTopSizeMultiRuneCorner(t *testing.T) {
+	b := Border{Top: "═", TopLeft: "╔═", TopRight: "═╗"}
+
+	if got := b.GetTopSize(); got != 2 {
+		t.Errorf("GetTopSize() = %d, want 2", got)
+	}
+}
+
+func TestASCIIOnlyStyle(t *testing.T) {
+	style := NewStyle().Border(NormalBorder()).ASCIIOnly(true)
+
+	if !style.GetASCIIOnly() {
+		t.Error("expected GetASCIIOnly to report true after ASCIIOnly(true)")
+	}
+
+	got := style.Render("hi")
+	want := "+--+\n|hi|\n+--+"
+
+	if got != want {
+		t.Errorf("ASCII border rendering mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSetUnicode(t *testing.T) {
+	SetUnicode(false)
+	defer SetUnicode(true)
+
+	style := NewStyle().Border(RoundedBorder())
+
+	got := style.Render("hi")
+	want := "+--+\n|hi|\n+--+"
+
+	if got != want {
+		t.Errorf("package-wide ASCII rendering mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRegisterASCIIFallback(t *testing.T) {
+	custom := Border{Top: "~", Bottom: "~", Left: "!", Right: "!", TopLeft: "@", TopRight: "@", BottomLeft: "@", BottomRight: "@"}
+	RegisterASCIIFallback('~', '-')
+	RegisterASCIIFallback('!', '|')
+	RegisterASCIIFallback('@', '+')
+
+	style := NewStyle().Border(custom).ASCIIOnly(true)
+
+	got := style.Render("hi")
+	want := "+--+\n|hi|\n+--+"
+
+	if got != want {
+		t.Errorf("custom ASCII fallback rendering mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSetUnicodeRace(t *testing.T) {
+	var wg sync.WaitGroup
+	style := NewStyle().Border(NormalBorder())
+
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			SetUnicode(i%2 == 0)
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = style.Render("hi")
+			RegisterASCIIFallback('─', '-')
+		}()
+	}
+	wg.Wait()
+	SetUnicode(true)
+}
+
+func TestThinBlockBorder(t *testing.T) {
+	style := NewStyle().Border(ThinBlockBorder()).Padding(0, 1)
+
+	got := stripANSI(style.Render("hi"))
+	want := "▏🮂🮂🮂🮂▕\n▏ hi ▕\n▏🮃🮃🮃🮃▕"
+
+	if got != want {
+		t.Errorf("ThinBlockBorder rendering mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
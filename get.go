@@ -0,0 +1,27 @@
+package lipgloss
+
+// getAsString returns a rule's value as a string, or the empty string if
+// the rule isn't set or isn't a string.
+func (s Style) getAsString(k propKey) string {
+	v, ok := s.rules[k]
+	if !ok {
+		return ""
+	}
+	if str, ok := v.(string); ok {
+		return str
+	}
+	return ""
+}
+
+// getAsPosition returns a rule's value as a Position, or the zero Position
+// (Top/Left) if the rule isn't set or isn't a Position.
+func (s Style) getAsPosition(k propKey) Position {
+	v, ok := s.rules[k]
+	if !ok {
+		return Position(0)
+	}
+	if p, ok := v.(Position); ok {
+		return p
+	}
+	return Position(0)
+}
@@ -0,0 +1,108 @@
+package lipgloss
+
+import "strings"
+
+// JoinHorizontal joins a list of strings side by side along their vertical
+// axis, padding each block to the tallest block's height and aligning rows
+// according to pos. Block widths and heights are measured by grapheme
+// cluster via Size, so wide runes and emoji line up correctly.
+func JoinHorizontal(pos Position, strs ...string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	if len(strs) == 1 {
+		return strs[0]
+	}
+
+	blocks := make([][]string, len(strs))
+	maxHeight := 0
+	maxWidths := make([]int, len(strs))
+
+	for i, s := range strs {
+		w, h := Size(s)
+		blocks[i], _ = getLines(s)
+		maxWidths[i] = w
+		if h > maxHeight {
+			maxHeight = h
+		}
+	}
+
+	for i := range blocks {
+		blocks[i] = padLinesVertical(blocks[i], maxWidths[i], maxHeight, pos)
+	}
+
+	var b strings.Builder
+	for row := 0; row < maxHeight; row++ {
+		for _, block := range blocks {
+			b.WriteString(block[row])
+		}
+		if row < maxHeight-1 {
+			b.WriteRune('\n')
+		}
+	}
+	return b.String()
+}
+
+// JoinVertical joins a list of strings top to bottom, padding each block to
+// the widest block's width and aligning columns according to pos.
+func JoinVertical(pos Position, strs ...string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	if len(strs) == 1 {
+		return strs[0]
+	}
+
+	maxWidth := 0
+	for _, s := range strs {
+		if w := Width(s); w > maxWidth {
+			maxWidth = w
+		}
+	}
+
+	var b strings.Builder
+	for i, s := range strs {
+		b.WriteString(alignTextHorizontal(s, pos, maxWidth))
+		if i < len(strs)-1 {
+			b.WriteRune('\n')
+		}
+	}
+	return b.String()
+}
+
+// padLinesVertical pads lines to width (by grapheme cluster) and the block
+// to height, aligning the original content according to pos.
+func padLinesVertical(lines []string, width, height int, pos Position) []string {
+	for i, l := range lines {
+		if shortAmount := width - printableWidth(l); shortAmount > 0 {
+			lines[i] = l + strings.Repeat(" ", shortAmount)
+		}
+	}
+
+	extra := height - len(lines)
+	if extra <= 0 {
+		return lines
+	}
+
+	var top int
+	switch pos {
+	case Top:
+		top = 0
+	case Bottom:
+		top = extra
+	default:
+		top = extra * int(pos*100) / 100
+	}
+	bottom := extra - top
+
+	blank := strings.Repeat(" ", width)
+	padded := make([]string, 0, height)
+	for i := 0; i < top; i++ {
+		padded = append(padded, blank)
+	}
+	padded = append(padded, lines...)
+	for i := 0; i < bottom; i++ {
+		padded = append(padded, blank)
+	}
+	return padded
+}
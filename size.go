@@ -0,0 +1,40 @@
+package lipgloss
+
+import "strings"
+
+// Width returns the cell width of the widest line in a (possibly multi-line)
+// string. ANSI escape sequences are ignored and multi-codepoint grapheme
+// clusters (wide emoji, ZWJ sequences, flags, etc.) are measured as a single
+// unit, so the result matches what a terminal actually prints.
+func Width(str string) (width int) {
+	for _, l := range strings.Split(str, "\n") {
+		if w := printableWidth(l); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// Height returns the height of a string in lines.
+func Height(str string) int {
+	return strings.Count(str, "\n") + 1
+}
+
+// Size returns the width and height of a (possibly multi-line) string, as
+// measured by Width and Height.
+func Size(str string) (width, height int) {
+	return Width(str), Height(str)
+}
+
+// getLines splits a string into lines and reports the cell width of its
+// widest line, both by grapheme cluster so callers don't need to re-derive
+// it with their own (possibly per-rune) measurement.
+func getLines(s string) (lines []string, widest int) {
+	lines = strings.Split(s, "\n")
+	for _, l := range lines {
+		if w := printableWidth(l); w > widest {
+			widest = w
+		}
+	}
+	return lines, widest
+}